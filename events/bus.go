@@ -0,0 +1,50 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.opentelemetry.io/otel"
+)
+
+// tracerName identifies spans created while publishing or consuming domain events.
+const tracerName = "github.com/endalk200/user-api/events"
+
+// Bus publishes domain events through a watermill message.Publisher,
+// injecting W3C trace context into message metadata so consumer spans link
+// back to the producing HTTP span.
+type Bus struct {
+	publisher message.Publisher
+}
+
+// NewBus wraps an already-configured watermill publisher.
+func NewBus(publisher message.Publisher) *Bus {
+	return &Bus{publisher: publisher}
+}
+
+// Publish marshals event as JSON and publishes it to topic, propagating the
+// trace context carried by ctx.
+func (b *Bus) Publish(ctx context.Context, topic string, event any) error {
+	tracer := otel.Tracer(tracerName)
+	ctx, span := tracer.Start(ctx, "events.Publish "+topic)
+	defer span.End()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	msg := message.NewMessage(watermill.NewUUID(), payload)
+	msg.Metadata.Set("topic", topic)
+	injectTraceContext(ctx, msg)
+
+	if err := b.publisher.Publish(topic, msg); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+// Package events publishes domain events for user lifecycle changes through
+// a watermill message bus, carrying W3C trace context in message metadata so
+// consumer spans link back to the producing HTTP request (the same pattern
+// twhelp's core consumers use).
+package events
+
+// Topic names for the domain events published when a user is created,
+// updated, or deleted.
+const (
+	TopicUserCreated = "user.created"
+	TopicUserUpdated = "user.updated"
+	TopicUserDeleted = "user.deleted"
+)
+
+// UserEvent is the JSON payload published for every user lifecycle event.
+type UserEvent struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email,omitempty"`
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"log/slog"
+
+	"github.com/ThreeDotsLabs/watermill"
+)
+
+// slogAdapter bridges watermill's LoggerAdapter interface to the app's
+// standard slog.Logger so watermill's internal logs flow through the same
+// sink (and OTel log bridge) as the rest of the app.
+type slogAdapter struct {
+	logger *slog.Logger
+}
+
+// NewLoggerAdapter wraps logger for use as a watermill.LoggerAdapter.
+func NewLoggerAdapter(logger *slog.Logger) watermill.LoggerAdapter {
+	return slogAdapter{logger: logger}
+}
+
+func (a slogAdapter) Error(msg string, err error, fields watermill.LogFields) {
+	a.logger.Error(msg, append(toArgs(fields), "error", err)...)
+}
+
+func (a slogAdapter) Info(msg string, fields watermill.LogFields) {
+	a.logger.Info(msg, toArgs(fields)...)
+}
+
+func (a slogAdapter) Debug(msg string, fields watermill.LogFields) {
+	a.logger.Debug(msg, toArgs(fields)...)
+}
+
+func (a slogAdapter) Trace(msg string, fields watermill.LogFields) {
+	a.logger.Debug(msg, toArgs(fields)...)
+}
+
+func (a slogAdapter) With(fields watermill.LogFields) watermill.LoggerAdapter {
+	return slogAdapter{logger: a.logger.With(toArgs(fields)...)}
+}
+
+func toArgs(fields watermill.LogFields) []any {
+	args := make([]any, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+	return args
+}
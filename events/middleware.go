@@ -0,0 +1,27 @@
+package events
+
+import (
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TraceMiddleware extracts the W3C trace context carried in a message's
+// metadata and starts a consumer span as its child, so the handler runs
+// inside a span linked to the HTTP request that published the message.
+func TraceMiddleware() message.HandlerMiddleware {
+	return func(h message.HandlerFunc) message.HandlerFunc {
+		return func(msg *message.Message) ([]*message.Message, error) {
+			ctx := ExtractTraceContext(msg.Context(), msg)
+
+			tracer := otel.Tracer(tracerName)
+			ctx, span := tracer.Start(ctx, "events.Consume "+msg.Metadata.Get("topic"))
+			defer span.End()
+
+			span.SetAttributes(attribute.String("messaging.message_id", msg.UUID))
+
+			msg.SetContext(ctx)
+			return h(msg)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.opentelemetry.io/otel"
+)
+
+// metadataCarrier adapts watermill's message.Metadata to otel's
+// propagation.TextMapCarrier so trace context can ride along in message
+// headers instead of the payload.
+type metadataCarrier message.Metadata
+
+func (c metadataCarrier) Get(key string) string { return message.Metadata(c).Get(key) }
+func (c metadataCarrier) Set(key, value string) { message.Metadata(c).Set(key, value) }
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// injectTraceContext writes the span context from ctx into msg's metadata.
+func injectTraceContext(ctx context.Context, msg *message.Message) {
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(msg.Metadata))
+}
+
+// ExtractTraceContext reads the span context carried in msg's metadata and
+// returns a context that is its child, linking the consumer span to the
+// producing HTTP span.
+func ExtractTraceContext(ctx context.Context, msg *message.Message) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(msg.Metadata))
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+)
+
+// NewPubSub builds the watermill publisher/subscriber pair selected by
+// backend. Only "channel" (the default) is wired up today: an in-memory
+// Go channel bus scoped to this process, so the returned publisher and
+// subscriber only see each other's messages within the same process -- it
+// cannot be used to run a publisher and consumer in separate processes.
+// NATS/Kafka drivers slot in here the same way storage.Store backends slot
+// into initStore, without changing any caller, and would lift that
+// restriction.
+func NewPubSub(backend string, logger watermill.LoggerAdapter) (message.Publisher, message.Subscriber, error) {
+	switch backend {
+	case "", "channel":
+		gc := gochannel.NewGoChannel(gochannel.Config{}, logger)
+		return gc, gc, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported EVENT_BUS_BACKEND %q (only %q is wired up)", backend, "channel")
+	}
+}
@@ -13,24 +13,25 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/endalk200/user-api/events"
 	"github.com/endalk200/user-api/models"
 	"github.com/endalk200/user-api/storage"
 )
 
 // UserHandler handles HTTP requests for user operations
 type UserHandler struct {
-	store  *storage.JSONStore
+	store  storage.Store
 	logger *slog.Logger
+	bus    *events.Bus
 
 	// Custom metrics
-	userCounter    metric.Int64UpDownCounter // Current number of users
-	usersCreated   metric.Int64Counter       // Total users created
-	usersDeleted   metric.Int64Counter       // Total users deleted
-	userOperations metric.Int64Counter       // Total user operations by type
+	userCounter  metric.Int64UpDownCounter // Current number of users
+	usersCreated metric.Int64Counter       // Total users created
+	usersDeleted metric.Int64Counter       // Total users deleted
 }
 
 // NewUserHandler creates a new user handler with metrics
-func NewUserHandler(store *storage.JSONStore, logger *slog.Logger, meter metric.Meter) *UserHandler {
+func NewUserHandler(store storage.Store, logger *slog.Logger, meter metric.Meter, bus *events.Bus) *UserHandler {
 	// Initialize custom metrics
 	userCounter, err := meter.Int64UpDownCounter(
 		"user_api_users_total",
@@ -59,27 +60,18 @@ func NewUserHandler(store *storage.JSONStore, logger *slog.Logger, meter metric.
 		logger.Error("Failed to create users deleted metric", "error", err)
 	}
 
-	userOperations, err := meter.Int64Counter(
-		"user_api_operations_total",
-		metric.WithDescription("Total number of user operations"),
-		metric.WithUnit("{operations}"),
-	)
-	if err != nil {
-		logger.Error("Failed to create user operations metric", "error", err)
-	}
-
 	// Initialize user counter with current count from storage
 	handler := &UserHandler{
-		store:          store,
-		logger:         logger,
-		userCounter:    userCounter,
-		usersCreated:   usersCreated,
-		usersDeleted:   usersDeleted,
-		userOperations: userOperations,
+		store:        store,
+		logger:       logger,
+		bus:          bus,
+		userCounter:  userCounter,
+		usersCreated: usersCreated,
+		usersDeleted: usersDeleted,
 	}
 
 	// Set initial user count
-	if users, err := store.GetAll(); err == nil {
+	if users, err := store.GetAll(context.Background()); err == nil {
 		userCounter.Add(context.Background(), int64(len(users)))
 	}
 
@@ -93,9 +85,8 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 	span := trace.SpanFromContext(ctx)
 
 	h.logger.InfoContext(ctx, "Fetching all users")
-	h.userOperations.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_all")))
 
-	users, err := h.store.GetAll()
+	users, err := h.store.GetAll(ctx)
 	if err != nil {
 		span.RecordError(err)
 		h.logger.ErrorContext(ctx, "Failed to fetch users", "error", err)
@@ -117,9 +108,8 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
 	span.SetAttributes(attribute.String("user.id", id))
 	h.logger.InfoContext(ctx, "Fetching user by ID", "user_id", id)
-	h.userOperations.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "get_by_id")))
 
-	user, err := h.store.GetByID(id)
+	user, err := h.store.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			h.logger.WarnContext(ctx, "User not found", "user_id", id)
@@ -142,8 +132,6 @@ func (h *UserHandler) Create(c *gin.Context) {
 	ctx := c.Request.Context()
 	span := trace.SpanFromContext(ctx)
 
-	h.userOperations.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "create")))
-
 	var req models.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.WarnContext(ctx, "Invalid request body", "error", err)
@@ -167,7 +155,7 @@ func (h *UserHandler) Create(c *gin.Context) {
 		attribute.String("user.email", user.Email),
 	)
 
-	if err := h.store.Create(user); err != nil {
+	if err := h.store.Create(ctx, user); err != nil {
 		if errors.Is(err, storage.ErrUserExists) {
 			h.logger.WarnContext(ctx, "User with email already exists", "email", req.Email)
 			c.JSON(http.StatusConflict, gin.H{"error": "User with this email already exists"})
@@ -183,6 +171,10 @@ func (h *UserHandler) Create(c *gin.Context) {
 	h.usersCreated.Add(ctx, 1)
 	h.userCounter.Add(ctx, 1)
 
+	if err := h.bus.Publish(ctx, events.TopicUserCreated, events.UserEvent{UserID: user.ID, Email: user.Email}); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to publish user.created event", "user_id", user.ID, "error", err)
+	}
+
 	h.logger.InfoContext(ctx, "Successfully created user", "user_id", user.ID)
 	c.JSON(http.StatusCreated, user)
 }
@@ -195,7 +187,6 @@ func (h *UserHandler) Update(c *gin.Context) {
 
 	id := c.Param("id")
 	span.SetAttributes(attribute.String("user.id", id))
-	h.userOperations.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "update")))
 
 	var req models.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -207,7 +198,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 	h.logger.InfoContext(ctx, "Updating user", "user_id", id, "name", req.Name, "email", req.Email)
 
 	// Get existing user to preserve created_at
-	existingUser, err := h.store.GetByID(id)
+	existingUser, err := h.store.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			h.logger.WarnContext(ctx, "User not found", "user_id", id)
@@ -228,7 +219,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 		UpdatedAt: time.Now(),
 	}
 
-	if err := h.store.Update(user); err != nil {
+	if err := h.store.Update(ctx, user); err != nil {
 		if errors.Is(err, storage.ErrUserExists) {
 			h.logger.WarnContext(ctx, "Email already in use", "email", req.Email)
 			c.JSON(http.StatusConflict, gin.H{"error": "Email already in use by another user"})
@@ -240,6 +231,10 @@ func (h *UserHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if err := h.bus.Publish(ctx, events.TopicUserUpdated, events.UserEvent{UserID: user.ID, Email: user.Email}); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to publish user.updated event", "user_id", id, "error", err)
+	}
+
 	h.logger.InfoContext(ctx, "Successfully updated user", "user_id", id)
 	c.JSON(http.StatusOK, user)
 }
@@ -253,9 +248,8 @@ func (h *UserHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 	span.SetAttributes(attribute.String("user.id", id))
 	h.logger.InfoContext(ctx, "Deleting user", "user_id", id)
-	h.userOperations.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", "delete")))
 
-	if err := h.store.Delete(id); err != nil {
+	if err := h.store.Delete(ctx, id); err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
 			h.logger.WarnContext(ctx, "User not found", "user_id", id)
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
@@ -271,6 +265,10 @@ func (h *UserHandler) Delete(c *gin.Context) {
 	h.usersDeleted.Add(ctx, 1)
 	h.userCounter.Add(ctx, -1)
 
+	if err := h.bus.Publish(ctx, events.TopicUserDeleted, events.UserEvent{UserID: id}); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to publish user.deleted event", "user_id", id, "error", err)
+	}
+
 	h.logger.InfoContext(ctx, "Successfully deleted user", "user_id", id)
 	c.JSON(http.StatusNoContent, nil)
 }
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// requestDurationBuckets matches the bucket boundaries Grafana's default
+// RED dashboards expect for http.server.request.duration.
+var requestDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics returns a Gin middleware that records the standard RED
+// (Rate/Errors/Duration) signals for every request: http.server.request.duration,
+// http.server.active_requests, and request/response body sizes. Every
+// measurement is tagged with http.route (via c.FullPath(), so "/users/:id"
+// stays low cardinality instead of the raw path) and http.method; request
+// duration and response body size, recorded after the handler runs, also
+// carry http.status_code and otel.status_code, so Prometheus/Grafana can
+// chart rate, error rate, and latency out of the box.
+func Metrics(meter metric.Meter, logger *slog.Logger) gin.HandlerFunc {
+	requestDuration, err := meter.Float64Histogram(
+		"http.server.request.duration",
+		metric.WithDescription("Duration of HTTP server requests"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(requestDurationBuckets...),
+	)
+	if err != nil {
+		logger.Error("Failed to create http.server.request.duration metric", "error", err)
+	}
+
+	activeRequests, err := meter.Int64UpDownCounter(
+		"http.server.active_requests",
+		metric.WithDescription("Number of in-flight HTTP server requests"),
+		metric.WithUnit("{requests}"),
+	)
+	if err != nil {
+		logger.Error("Failed to create http.server.active_requests metric", "error", err)
+	}
+
+	requestBodySize, err := meter.Int64Histogram(
+		"http.server.request.body.size",
+		metric.WithDescription("Size of HTTP server request bodies"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		logger.Error("Failed to create http.server.request.body.size metric", "error", err)
+	}
+
+	responseBodySize, err := meter.Int64Histogram(
+		"http.server.response.body.size",
+		metric.WithDescription("Size of HTTP server response bodies"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		logger.Error("Failed to create http.server.response.body.size metric", "error", err)
+	}
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		route := c.FullPath()
+		if route == "" {
+			route = "not_found"
+		}
+
+		routeAttr := attribute.String("http.route", route)
+		methodAttr := attribute.String("http.method", c.Request.Method)
+
+		activeRequests.Add(ctx, 1, metric.WithAttributes(routeAttr, methodAttr))
+		defer activeRequests.Add(ctx, -1, metric.WithAttributes(routeAttr, methodAttr))
+
+		requestBodySize.Record(ctx, c.Request.ContentLength, metric.WithAttributes(routeAttr, methodAttr))
+
+		start := time.Now()
+		c.Next()
+		duration := time.Since(start).Seconds()
+
+		status := c.Writer.Status()
+		otelStatus := "OK"
+		if status >= 500 {
+			otelStatus = "ERROR"
+		}
+
+		attrs := metric.WithAttributes(
+			routeAttr,
+			methodAttr,
+			attribute.Int("http.status_code", status),
+			attribute.String("otel.status_code", otelStatus),
+		)
+
+		requestDuration.Record(ctx, duration, attrs)
+		responseBodySize.Record(ctx, int64(c.Writer.Size()), attrs)
+	}
+}
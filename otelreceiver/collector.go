@@ -0,0 +1,87 @@
+// Package otelreceiver embeds an OTLP receiver directly in the user-api
+// process, following the pattern go-faster/oteldb uses to embed otelcol
+// components. Gated behind ENABLE_EMBEDDED_COLLECTOR, it lets other
+// services on the same host send OTLP/gRPC and OTLP/HTTP telemetry straight
+// to user-api, which forwards it through a memory_limiter, batch, and
+// attributes processor chain, with the same auth headers, CA file, and
+// general insecure setting the app's own exporters use (client
+// certificates are not applied to the forwarder) — useful for edge/dev
+// environments where the docker-compose collector is overkill.
+package otelreceiver
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+)
+
+// New builds an in-process otelcol.Collector that receives OTLP on cfg's
+// endpoints and forwards everything to cfg.ForwardEndpoint. Call Run on the
+// result to start it; Run blocks until the context passed to it is canceled.
+func New(cfg Config) (*otelcol.Collector, error) {
+	cfg = cfg.withDefaults()
+
+	factories, err := buildFactories()
+	if err != nil {
+		return nil, err
+	}
+
+	return otelcol.NewCollector(otelcol.CollectorSettings{
+		Factories: func() (otelcol.Factories, error) { return factories, nil },
+		BuildInfo: component.BuildInfo{
+			Command:     "user-api",
+			Description: "user-api embedded OTLP collector",
+		},
+		ConfigProviderSettings: otelcol.ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{"yaml:" + pipelineYAML(cfg)},
+				ProviderFactories: []confmap.ProviderFactory{yamlprovider.NewFactory()},
+			},
+		},
+	})
+}
+
+// buildFactories registers the receiver/processor/exporter set the embedded
+// pipeline needs: an OTLP receiver, the batch/memory_limiter/attributes
+// processor chain, and an OTLP exporter to re-forward everything.
+//
+// otelcol.Factories keys each map by component.Type, and receiver.MakeFactoryMap
+// et al. were removed upstream, so the maps are built directly here.
+func buildFactories() (otelcol.Factories, error) {
+	receiverFactories := []receiver.Factory{otlpreceiver.NewFactory()}
+	receivers := make(map[component.Type]receiver.Factory, len(receiverFactories))
+	for _, f := range receiverFactories {
+		receivers[f.Type()] = f
+	}
+
+	processorFactories := []processor.Factory{
+		batchprocessor.NewFactory(),
+		memorylimiterprocessor.NewFactory(),
+		attributesprocessor.NewFactory(),
+	}
+	processors := make(map[component.Type]processor.Factory, len(processorFactories))
+	for _, f := range processorFactories {
+		processors[f.Type()] = f
+	}
+
+	exporterFactories := []exporter.Factory{otlpexporter.NewFactory()}
+	exporters := make(map[component.Type]exporter.Factory, len(exporterFactories))
+	for _, f := range exporterFactories {
+		exporters[f.Type()] = f
+	}
+
+	return otelcol.Factories{
+		Receivers:  receivers,
+		Processors: processors,
+		Exporters:  exporters,
+	}, nil
+}
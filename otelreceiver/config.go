@@ -0,0 +1,37 @@
+package otelreceiver
+
+// Config configures the embedded OTLP receiver that lets other services in
+// the same environment send telemetry to user-api instead of standing up a
+// separate collector container.
+type Config struct {
+	// GRPCEndpoint is where the embedded OTLP/gRPC receiver listens.
+	GRPCEndpoint string
+	// HTTPEndpoint is where the embedded OTLP/HTTP receiver listens.
+	HTTPEndpoint string
+	// ForwardEndpoint is the OTLP endpoint telemetry is forwarded to — the
+	// same backend the app's own exporters use.
+	ForwardEndpoint string
+	// ForwardHeaders are attached to every forwarded request, e.g. the
+	// Authorization header a vendor endpoint like Grafana Cloud requires.
+	// Should mirror the app's own OTEL_EXPORTER_OTLP_HEADERS so embedded
+	// and app-originated telemetry are authenticated the same way.
+	ForwardHeaders map[string]string
+	// ForwardCACertPath optionally verifies ForwardEndpoint against a
+	// custom CA, mirroring OTEL_EXPORTER_OTLP_CERTIFICATE. Leave empty to
+	// verify against the system root pool.
+	ForwardCACertPath string
+	// ForwardInsecure disables TLS when dialing ForwardEndpoint. TLS is the
+	// default — same as the app's own exporters — so this should only be
+	// set from an explicit opt-out, never assumed true.
+	ForwardInsecure bool
+}
+
+func (c Config) withDefaults() Config {
+	if c.GRPCEndpoint == "" {
+		c.GRPCEndpoint = "0.0.0.0:4317"
+	}
+	if c.HTTPEndpoint == "" {
+		c.HTTPEndpoint = "0.0.0.0:4318"
+	}
+	return c
+}
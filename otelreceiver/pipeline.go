@@ -0,0 +1,90 @@
+package otelreceiver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// pipelineYAML renders the otelcol pipeline config: an OTLP receiver on
+// both gRPC and HTTP, a batch/memory_limiter/attributes processor chain,
+// and an OTLP/gRPC exporter that forwards to cfg.ForwardEndpoint with
+// cfg's headers, CA file, and insecure setting. The processor chain is
+// fixed: Config has no field to add, remove, or reorder processors, and
+// buildFactories registers only these three processors.
+func pipelineYAML(cfg Config) string {
+	insecure := "false"
+	if cfg.ForwardInsecure {
+		insecure = "true"
+	}
+
+	tlsBlock := "      insecure: " + insecure
+	if cfg.ForwardCACertPath != "" {
+		tlsBlock += "\n      ca_file: " + cfg.ForwardCACertPath
+	}
+
+	return fmt.Sprintf(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: %s
+      http:
+        endpoint: %s
+
+processors:
+  memory_limiter:
+    check_interval: 1s
+    limit_mib: 256
+  batch:
+    timeout: 5s
+  attributes:
+    actions:
+      - key: forwarded_by
+        value: user-api-embedded-collector
+        action: upsert
+
+exporters:
+  otlp:
+    endpoint: %s
+    tls:
+%s%s
+
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [memory_limiter, batch, attributes]
+      exporters: [otlp]
+    metrics:
+      receivers: [otlp]
+      processors: [memory_limiter, batch, attributes]
+      exporters: [otlp]
+    logs:
+      receivers: [otlp]
+      processors: [memory_limiter, batch, attributes]
+      exporters: [otlp]
+`, cfg.GRPCEndpoint, cfg.HTTPEndpoint, cfg.ForwardEndpoint, tlsBlock, headersYAML(cfg.ForwardHeaders))
+}
+
+// headersYAML renders an otlpexporter "headers:" block for the given
+// headers (e.g. Authorization for a vendor endpoint), sorted by key so the
+// rendered config is deterministic. Returns "" when there are none.
+func headersYAML(headers map[string]string) string {
+	if len(headers) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("\n    headers:")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n      %s: %q", k, headers[k])
+	}
+	return b.String()
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
@@ -82,8 +83,9 @@ func (s *JSONStore) save() error {
 	return os.WriteFile(s.filePath, data, 0644)
 }
 
-// GetAll returns all users
-func (s *JSONStore) GetAll() ([]models.User, error) {
+// GetAll returns all users. The in-memory store has no I/O to trace, so ctx
+// is accepted only to satisfy the Store interface.
+func (s *JSONStore) GetAll(ctx context.Context) ([]models.User, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -96,7 +98,7 @@ func (s *JSONStore) GetAll() ([]models.User, error) {
 }
 
 // GetByID returns a user by ID
-func (s *JSONStore) GetByID(id string) (models.User, error) {
+func (s *JSONStore) GetByID(ctx context.Context, id string) (models.User, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -109,7 +111,7 @@ func (s *JSONStore) GetByID(id string) (models.User, error) {
 }
 
 // Create adds a new user
-func (s *JSONStore) Create(user models.User) error {
+func (s *JSONStore) Create(ctx context.Context, user models.User) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -125,7 +127,7 @@ func (s *JSONStore) Create(user models.User) error {
 }
 
 // Update modifies an existing user
-func (s *JSONStore) Update(user models.User) error {
+func (s *JSONStore) Update(ctx context.Context, user models.User) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -145,7 +147,7 @@ func (s *JSONStore) Update(user models.User) error {
 }
 
 // Delete removes a user by ID
-func (s *JSONStore) Delete(id string) error {
+func (s *JSONStore) Delete(ctx context.Context, id string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
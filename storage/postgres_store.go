@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/extra/bunotel"
+
+	"github.com/endalk200/user-api/models"
+)
+
+// pgUniqueViolation is the PostgreSQL SQLSTATE for unique_violation.
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const pgUniqueViolation = "23505"
+
+// userRow is the bun model backing the "users" table. It mirrors
+// models.User but keeps the column mapping local to the postgres backend.
+type userRow struct {
+	bun.BaseModel `bun:"table:users,alias:u"`
+
+	ID        string    `bun:"id,pk"`
+	Name      string    `bun:"name,notnull"`
+	Email     string    `bun:"email,notnull,unique"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+	UpdatedAt time.Time `bun:"updated_at,notnull"`
+}
+
+func (r userRow) toUser() models.User {
+	return models.User{
+		ID:        r.ID,
+		Name:      r.Name,
+		Email:     r.Email,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func userToRow(user models.User) userRow {
+	return userRow{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// NewPostgresDB opens a *bun.DB against dsn and registers bunotel's query
+// hook so every statement becomes a child span of the request's trace,
+// alongside the HTTP span created by otelgin.
+func NewPostgresDB(dsn string) *bun.DB {
+	sqldb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn)))
+	db := bun.NewDB(sqldb, pgdialect.New())
+	db.AddQueryHook(bunotel.NewQueryHook(
+		bunotel.WithDBName("user_api"),
+		bunotel.WithFormattedQueries(true),
+	))
+	return db
+}
+
+// Migrate creates the users table if it does not already exist. It is
+// intentionally idempotent so it can run on every startup.
+func Migrate(ctx context.Context, db *bun.DB) error {
+	_, err := db.NewCreateTable().
+		Model((*userRow)(nil)).
+		IfNotExists().
+		Exec(ctx)
+	return err
+}
+
+// PostgresStore persists users in Postgres via uptrace/bun.
+type PostgresStore struct {
+	db *bun.DB
+}
+
+// NewPostgresStore wraps an already-connected *bun.DB.
+func NewPostgresStore(db *bun.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// GetAll returns all users.
+func (s *PostgresStore) GetAll(ctx context.Context) ([]models.User, error) {
+	var rows []userRow
+	if err := s.db.NewSelect().Model(&rows).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	users := make([]models.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, row.toUser())
+	}
+
+	return users, nil
+}
+
+// GetByID returns a user by ID.
+func (s *PostgresStore) GetByID(ctx context.Context, id string) (models.User, error) {
+	row := userRow{ID: id}
+	if err := s.db.NewSelect().Model(&row).WherePK().Scan(ctx); err != nil {
+		if err == sql.ErrNoRows {
+			return models.User{}, ErrUserNotFound
+		}
+		return models.User{}, err
+	}
+
+	return row.toUser(), nil
+}
+
+// Create adds a new user. Email uniqueness is enforced by the table's
+// unique constraint rather than a pre-check: a SELECT-then-INSERT check has
+// an inherent race (two concurrent requests for the same email can both
+// pass it), so the insert itself is the source of truth and its
+// unique_violation is mapped to ErrUserExists.
+func (s *PostgresStore) Create(ctx context.Context, user models.User) error {
+	row := userToRow(user)
+	if _, err := s.db.NewInsert().Model(&row).Exec(ctx); err != nil {
+		if isUniqueViolation(err) {
+			return ErrUserExists
+		}
+		return err
+	}
+	return nil
+}
+
+// Update modifies an existing user. See Create for why email uniqueness is
+// enforced via the unique constraint instead of a racy pre-check.
+func (s *PostgresStore) Update(ctx context.Context, user models.User) error {
+	if _, err := s.GetByID(ctx, user.ID); err != nil {
+		return err
+	}
+
+	row := userToRow(user)
+	if _, err := s.db.NewUpdate().Model(&row).WherePK().Exec(ctx); err != nil {
+		if isUniqueViolation(err) {
+			return ErrUserExists
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes a user by ID.
+func (s *PostgresStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	row := userRow{ID: id}
+	_, err := s.db.NewDelete().Model(&row).WherePK().Exec(ctx)
+	return err
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation,
+// e.g. from the users.email unique constraint.
+func isUniqueViolation(err error) bool {
+	var pgErr pgdriver.Error
+	return errors.As(err, &pgErr) && pgErr.Field('C') == pgUniqueViolation
+}
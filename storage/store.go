@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/endalk200/user-api/models"
+)
+
+// Store is the storage-agnostic interface consumed by the handlers package.
+// JSONStore and PostgresStore both satisfy it, so main.go can switch
+// backends at runtime without the rest of the application knowing which
+// one is active. Every method takes the request's context so a backend
+// like PostgresStore can attach its spans (via bunotel) as children of the
+// incoming HTTP span instead of starting disconnected traces.
+type Store interface {
+	GetAll(ctx context.Context) ([]models.User, error)
+	GetByID(ctx context.Context, id string) (models.User, error)
+	Create(ctx context.Context, user models.User) error
+	Update(ctx context.Context, user models.User) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,324 @@
+// Package telemetry builds OTLP exporters from the standard OpenTelemetry
+// environment variables, so the app can be pointed at a vendor endpoint
+// (Grafana Cloud, etc.) that needs TLS and auth headers without recompiling.
+package telemetry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// signal identifies which OTLP data stream an exporter carries, so
+// per-signal endpoint overrides (OTEL_EXPORTER_OTLP_<SIGNAL>_ENDPOINT) take
+// precedence over the general OTEL_EXPORTER_OTLP_ENDPOINT.
+type signal string
+
+const (
+	signalTraces  signal = "TRACES"
+	signalMetrics signal = "METRICS"
+	signalLogs    signal = "LOGS"
+
+	defaultEndpoint = "localhost:4317"
+)
+
+// protocol returns the configured OTLP wire protocol: "grpc" (default),
+// "http/protobuf", or "http/json".
+func protocol() string {
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); p != "" {
+		return p
+	}
+	return "grpc"
+}
+
+// endpoint resolves the endpoint for sig, honoring the per-signal override
+// before falling back to the general endpoint and finally a localhost default.
+func endpoint(sig signal) string {
+	if e := os.Getenv(fmt.Sprintf("OTEL_EXPORTER_OTLP_%s_ENDPOINT", sig)); e != "" {
+		return e
+	}
+	if e := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); e != "" {
+		return e
+	}
+	return defaultEndpoint
+}
+
+// headers parses OTEL_EXPORTER_OTLP_HEADERS ("key1=value1,key2=value2") as
+// defined by the OTel spec, e.g. for vendor Authorization tokens.
+func headers() map[string]string {
+	raw := os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")
+	if raw == "" {
+		return nil
+	}
+
+	result := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(kv[1])
+		if decoded, err := url.PathUnescape(value); err == nil {
+			value = decoded
+		}
+		result[strings.TrimSpace(kv[0])] = value
+	}
+	return result
+}
+
+// insecure reports whether sig's exporter should use a plaintext transport,
+// via the per-signal OTEL_EXPORTER_OTLP_<SIGNAL>_INSECURE or the general
+// OTEL_EXPORTER_OTLP_INSECURE. TLS is the default: vendor endpoints (Grafana
+// Cloud and friends) expect plain TLS with the system CA pool plus an
+// Authorization header, not a custom CA file, so insecure must be opted into
+// explicitly rather than being the fallback whenever no CA is configured.
+func insecure(sig signal) bool {
+	if v := os.Getenv(fmt.Sprintf("OTEL_EXPORTER_OTLP_%s_INSECURE", sig)); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_INSECURE"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return false
+}
+
+// Headers parses OTEL_EXPORTER_OTLP_HEADERS, exported so callers that build
+// their own OTLP exporters outside this package (e.g. the embedded
+// collector's forward exporter) can send the same vendor auth headers as
+// NewTraceExporter/NewMetricExporter/NewLogExporter.
+func Headers() map[string]string {
+	return headers()
+}
+
+// CACertificatePath returns OTEL_EXPORTER_OTLP_CERTIFICATE, the CA file (if
+// any) used to verify a custom collector or vendor endpoint, for callers
+// that need to apply the same TLS policy tlsConfig does outside this package.
+func CACertificatePath() string {
+	return os.Getenv("OTEL_EXPORTER_OTLP_CERTIFICATE")
+}
+
+// Insecure reports whether OTEL_EXPORTER_OTLP_INSECURE opts into a
+// plaintext transport, mirroring the general fallback insecure() uses for
+// this package's own exporters.
+func Insecure() bool {
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_INSECURE"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return false
+}
+
+// tlsConfig builds the *tls.Config used for secure exporters. With no CA
+// configured it returns a zero-value config, which makes Go's transport
+// verify against the system root CA pool -- the right default for hitting a
+// public vendor OTLP endpoint. OTEL_EXPORTER_OTLP_CERTIFICATE overrides the
+// CA, and OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE / _CLIENT_KEY add mTLS.
+func tlsConfig() (*tls.Config, error) {
+	caPath := os.Getenv("OTEL_EXPORTER_OTLP_CERTIFICATE")
+	clientCertPath := os.Getenv("OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE")
+	clientKeyPath := os.Getenv("OTEL_EXPORTER_OTLP_CLIENT_KEY")
+
+	cfg := &tls.Config{}
+
+	if caPath != "" {
+		ca, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading OTLP CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s", caPath)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if clientCertPath != "" && clientKeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading OTLP client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// Sampler builds the trace sampler from OTEL_TRACES_SAMPLER /
+// OTEL_TRACES_SAMPLER_ARG, wrapped in ParentBased so a sampled parent
+// context is always respected regardless of the local decision.
+func Sampler() sdktrace.Sampler {
+	switch os.Getenv("OTEL_TRACES_SAMPLER") {
+	case "always_on":
+		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+	case "always_off":
+		return sdktrace.ParentBased(sdktrace.NeverSample())
+	default:
+		ratio := 1.0
+		if arg := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); arg != "" {
+			if parsed, err := strconv.ParseFloat(arg, 64); err == nil {
+				ratio = parsed
+			}
+		}
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+	}
+}
+
+// MetricExportInterval reads OTEL_METRIC_EXPORT_INTERVAL (milliseconds),
+// defaulting to 15s when it is unset or not an integer.
+func MetricExportInterval() time.Duration {
+	raw := os.Getenv("OTEL_METRIC_EXPORT_INTERVAL")
+	if raw == "" {
+		return 15 * time.Second
+	}
+
+	ms, err := strconv.Atoi(raw)
+	if err != nil {
+		return 15 * time.Second
+	}
+
+	return time.Duration(ms) * time.Millisecond
+}
+
+// NewTraceExporter builds the trace exporter selected by
+// OTEL_EXPORTER_OTLP_PROTOCOL, honoring per-signal endpoint, TLS, and header
+// overrides.
+func NewTraceExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	if isHTTP() {
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(endpoint(signalTraces)),
+			otlptracehttp.WithHeaders(headers()),
+		}
+		if insecure(signalTraces) {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else {
+			tlsCfg, err := tlsConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(endpoint(signalTraces)),
+		otlptracegrpc.WithHeaders(headers()),
+	}
+	if insecure(signalTraces) {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else {
+		tlsCfg, err := tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// NewMetricExporter builds the metric exporter selected by
+// OTEL_EXPORTER_OTLP_PROTOCOL, honoring per-signal endpoint, TLS, and header
+// overrides.
+func NewMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	if isHTTP() {
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(endpoint(signalMetrics)),
+			otlpmetrichttp.WithHeaders(headers()),
+		}
+		if insecure(signalMetrics) {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		} else {
+			tlsCfg, err := tlsConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsCfg))
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(endpoint(signalMetrics)),
+		otlpmetricgrpc.WithHeaders(headers()),
+	}
+	if insecure(signalMetrics) {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	} else {
+		tlsCfg, err := tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	}
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
+// NewLogExporter builds the log exporter selected by
+// OTEL_EXPORTER_OTLP_PROTOCOL, honoring per-signal endpoint, TLS, and header
+// overrides.
+func NewLogExporter(ctx context.Context) (sdklog.Exporter, error) {
+	if isHTTP() {
+		opts := []otlploghttp.Option{
+			otlploghttp.WithEndpoint(endpoint(signalLogs)),
+			otlploghttp.WithHeaders(headers()),
+		}
+		if insecure(signalLogs) {
+			opts = append(opts, otlploghttp.WithInsecure())
+		} else {
+			tlsCfg, err := tlsConfig()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, otlploghttp.WithTLSClientConfig(tlsCfg))
+		}
+		return otlploghttp.New(ctx, opts...)
+	}
+
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpoint(endpoint(signalLogs)),
+		otlploggrpc.WithHeaders(headers()),
+	}
+	if insecure(signalLogs) {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	} else {
+		tlsCfg, err := tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+	}
+	return otlploggrpc.New(ctx, opts...)
+}
+
+// isHTTP reports whether OTEL_EXPORTER_OTLP_PROTOCOL selects an HTTP
+// transport instead of the gRPC default.
+func isHTTP() bool {
+	switch protocol() {
+	case "http/protobuf", "http/json":
+		return true
+	default:
+		return false
+	}
+}